@@ -19,6 +19,11 @@ type App struct {
 	OllamaBaseDir string
 	OutputDir     string
 	Debug         bool
+	Format        string
+	BundlePath    string
+	OllamaHost    string
+	Pull          bool
+	Show          bool
 }
 
 // NewApp creates a new App instance
@@ -27,9 +32,24 @@ func NewApp(ollamaBaseDir, outputDir string, debug bool) *App {
 		OllamaBaseDir: ollamaBaseDir,
 		OutputDir:     outputDir,
 		Debug:         debug,
+		Format:        "tar",
+		OllamaHost:    defaultOllamaHost,
 	}
 }
 
+// SetFormat validates and sets the export format ("tar" or "oci").
+func (a *App) SetFormat(format string) error {
+	switch format {
+	case "", "tar":
+		a.Format = "tar"
+	case "oci":
+		a.Format = "oci"
+	default:
+		return fmt.Errorf("unsupported export format %q (expected \"tar\" or \"oci\")", format)
+	}
+	return nil
+}
+
 // Run executes the main application logic
 func (a *App) Run(models ...string) {
 	// Check if the required directories exist
@@ -54,33 +74,50 @@ func (a *App) Run(models ...string) {
 	} else {
 		fmt.Println("Exporting all available models in ollama:")
 		var err error
-		modelsToExport, err = getOllamaModelsWithTags()
+		modelsToExport, err = a.getOllamaModelsWithTags()
 		if err != nil {
 			errorExit(err.Error())
 		}
 		fmt.Println(strings.Join(modelsToExport, " "))
 	}
 
+	if a.BundlePath != "" {
+		a.runBundleExport(modelsToExport)
+		return
+	}
+
 	// Iterate over models to export
 	for _, modelFull := range modelsToExport {
-		// Compress the export
-		fmt.Printf("Compressing model: %s\n", modelFull)
-		safeModelName := strings.ReplaceAll(modelFull, ":", "-")
-		outputFileName := fmt.Sprintf("ollama-export-%s.tar.gz", safeModelName)
-		outputFilePath := filepath.Join(a.OutputDir, outputFileName)
+		name, err := ParseModelName(modelFull)
+		if err != nil {
+			fmt.Printf("WARNING: %v, skipping.\n", err)
+			continue
+		}
 
-		modelNameParts := strings.Split(modelFull, ":")
-		modelBaseName := modelNameParts[0]
-		modelTag := "latest"
-		if len(modelNameParts) > 1 {
-			modelTag = modelNameParts[1]
+		// Compress the export
+		fmt.Printf("Compressing model: %s\n", name)
+		outputFileName := fmt.Sprintf("ollama-export-%s.tar.gz", name.SafeFileName())
+		if a.Format == "oci" {
+			outputFileName = fmt.Sprintf("ollama-export-%s-oci.tar.gz", name.SafeFileName())
 		}
+		outputFilePath := filepath.Join(a.OutputDir, outputFileName)
 
-		manifestPath := filepath.Join(a.OllamaBaseDir, "manifests/registry.ollama.ai/library", modelBaseName, modelTag)
+		manifestPath := filepath.Join(a.OllamaBaseDir, name.ManifestPath())
 
 		if _, err := os.Stat(manifestPath); os.IsNotExist(err) {
-			fmt.Printf("WARNING: Manifest for model '%s' not found, skipping.\n", modelFull)
-			continue
+			if !a.Pull {
+				fmt.Printf("WARNING: Manifest for model '%s' not found, skipping.\n", modelFull)
+				continue
+			}
+			fmt.Printf("Model '%s' not found locally, pulling from %s:\n", name, a.OllamaHost)
+			if err := a.pullModel(name); err != nil {
+				fmt.Printf("WARNING: Failed to pull model '%s': %v, skipping.\n", name, err)
+				continue
+			}
+			if _, err := os.Stat(manifestPath); os.IsNotExist(err) {
+				fmt.Printf("WARNING: Manifest for model '%s' still not found after pull, skipping.\n", name)
+				continue
+			}
 		}
 
 		manifestFile, err := os.ReadFile(manifestPath)
@@ -107,11 +144,30 @@ func (a *App) Run(models ...string) {
 		}
 		filesToCompress = append(filesToCompress, manifestPath)
 
+		extraFiles := map[string][]byte{}
+		if a.Show {
+			if a.Format == "oci" {
+				fmt.Println("NOTE: --show is not supported with --format oci, skipping Modelfile embed.")
+			} else if modelfile, err := a.fetchModelfile(name); err != nil {
+				fmt.Printf("WARNING: Failed to fetch Modelfile for '%s': %v\n", name, err)
+			} else {
+				// Kept outside the "manifests/" namespace so import doesn't mistake it
+				// for a manifest entry.
+				entryName := filepath.Join("modelfiles", name.Host, name.Namespace, name.Model, name.Tag, "Modelfile")
+				extraFiles[entryName] = []byte(modelfile)
+			}
+		}
+
 		s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
 		s.Suffix = " Compressing..."
 		s.Start()
 
-		if err := createTarGz(outputFilePath, filesToCompress, a.OllamaBaseDir); err != nil {
+		if a.Format == "oci" {
+			if err := createOCIArchive(outputFilePath, a.OllamaBaseDir, name.Model+":"+name.Tag, manifestData); err != nil {
+				s.Stop()
+				errorExit(fmt.Sprintf("Failed to create OCI image archive: %v", err))
+			}
+		} else if err := createTarGz(outputFilePath, filesToCompress, a.OllamaBaseDir, extraFiles); err != nil {
 			s.Stop()
 			errorExit(fmt.Sprintf("Failed to create tar.gz archive: %v", err))
 		}
@@ -120,17 +176,26 @@ func (a *App) Run(models ...string) {
 
 		fmt.Println("===================================================")
 		fmt.Printf("Export completed: %s\n", outputFilePath)
-		fmt.Println("To import on the destination system:")
-		fmt.Println("1. Decompress with: tar -xzvf ollama-export.tar.gz -C /destination/path")
-		fmt.Println("2. Copy the files to the Docker container: docker cp /destination/path/. [ollama-container]:/root/.ollama/")
-		fmt.Println("3. Register the models in the container(inside the container): echo \"FROM nombremodelo:tag\" > Modelfile")
-		fmt.Println("4. ollama create --model [nombremodelo:tag] --file Modelfile")
+		if a.Format == "oci" {
+			fmt.Println("This is an OCI image layout archive, consumable directly by OCI-aware tools:")
+			fmt.Printf("  skopeo copy oci-archive:%s docker-daemon:%s:%s\n", outputFilePath, name.Model, name.Tag)
+		} else {
+			fmt.Println("To import on the destination system:")
+			fmt.Printf("1. Run: gollama-export import -o /destination/path %s\n", outputFilePath)
+			fmt.Println("   (or decompress manually with: tar -xzvf " + outputFileName + " -C /destination/path)")
+			fmt.Println("2. Copy the files to the Docker container: docker cp /destination/path/. [ollama-container]:/root/.ollama/")
+			fmt.Printf("3. Register the model (inside the container): echo \"FROM %s\" > Modelfile\n", name)
+			fmt.Printf("4. ollama create --model %s --file Modelfile\n", name)
+		}
 		fmt.Println("===================================================")
 		fmt.Println("Export finished.")
 	}
 }
 
-func createTarGz(buf string, files []string, baseDir string) error {
+// createTarGz writes files (relative to baseDir) into buf as a gzip-compressed tar
+// archive, plus any extraFiles written verbatim at their given archive-relative names
+// (e.g. a fetched Modelfile that has no corresponding file on disk).
+func createTarGz(buf string, files []string, baseDir string, extraFiles map[string][]byte) error {
 	// Create output file
 	outFile, err := os.Create(buf)
 	if err != nil {
@@ -153,6 +218,12 @@ func createTarGz(buf string, files []string, baseDir string) error {
 		}
 	}
 
+	for name, data := range extraFiles {
+		if err := writeTarBytes(tw, name, data); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -189,4 +260,4 @@ func addFileToTar(tw *tar.Writer, path string, baseDir string) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
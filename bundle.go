@@ -0,0 +1,179 @@
+// bundle.go
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// bundleModelEntry records one model included in a bundle archive and the manifest
+// digest it was exported at.
+type bundleModelEntry struct {
+	Model          string `json:"model"`
+	ManifestDigest string `json:"manifestDigest"`
+}
+
+// bundleManifest is the top-level "bundle.json" entry of a --bundle archive.
+type bundleManifest struct {
+	Models []bundleModelEntry `json:"models"`
+}
+
+// runBundleExport collects the union of blob hashes across all requested models, writes
+// each blob exactly once under "blobs/sha256-<hash>", writes every manifest under its
+// canonical path, and adds a top-level "bundle.json" listing the included models. This
+// avoids the per-blob duplication of exporting each model into its own archive.
+func (a *App) runBundleExport(models []string) {
+	outFile, err := os.Create(a.BundlePath)
+	if err != nil {
+		errorExit(fmt.Sprintf("Failed to create bundle archive: %v", err))
+	}
+	defer outFile.Close()
+
+	gz := gzip.NewWriter(outFile)
+	defer gz.Close()
+
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+
+	blobSizes := map[string]int64{}
+	blobRefCount := map[string]int{}
+	writtenBlobs := map[string]bool{}
+	var bundleModels []bundleModelEntry
+
+	for _, modelFull := range models {
+		name, err := ParseModelName(modelFull)
+		if err != nil {
+			fmt.Printf("WARNING: %v, skipping.\n", err)
+			continue
+		}
+
+		manifestPath := filepath.Join(a.OllamaBaseDir, name.ManifestPath())
+		if _, err := os.Stat(manifestPath); os.IsNotExist(err) && a.Pull {
+			fmt.Printf("Model '%s' not found locally, pulling from %s:\n", name, a.OllamaHost)
+			if err := a.pullModel(name); err != nil {
+				fmt.Printf("WARNING: Failed to pull model '%s': %v, skipping.\n", name, err)
+				continue
+			}
+		}
+		manifestBytes, err := os.ReadFile(manifestPath)
+		if err != nil {
+			fmt.Printf("WARNING: Manifest for model '%s' not found, skipping.\n", name)
+			continue
+		}
+
+		var manifestData map[string]interface{}
+		if err := json.Unmarshal(manifestBytes, &manifestData); err != nil {
+			errorExit(fmt.Sprintf("Failed to unmarshal manifest JSON: %v", err))
+		}
+
+		fmt.Printf("Adding to bundle: %s\n", name)
+
+		for _, hash := range manifestBlobHashes(manifestData) {
+			blobRefCount[hash]++
+			if writtenBlobs[hash] {
+				continue
+			}
+
+			blobPath := filepath.Join(a.OllamaBaseDir, "blobs", "sha256-"+hash)
+			info, err := os.Stat(blobPath)
+			if err != nil {
+				fmt.Printf("WARNING: blob sha256-%s referenced by %s is missing, skipping.\n", hash, name)
+				continue
+			}
+
+			if err := writeTarFile(tw, "blobs/sha256-"+hash, blobPath); err != nil {
+				errorExit(fmt.Sprintf("Failed to add blob to bundle: %v", err))
+			}
+			writtenBlobs[hash] = true
+			blobSizes[hash] = info.Size()
+		}
+
+		if err := writeTarBytes(tw, name.ManifestPath(), manifestBytes); err != nil {
+			errorExit(fmt.Sprintf("Failed to add manifest to bundle: %v", err))
+		}
+
+		if a.Show {
+			if modelfile, err := a.fetchModelfile(name); err != nil {
+				fmt.Printf("WARNING: Failed to fetch Modelfile for '%s': %v\n", name, err)
+			} else if err := writeTarBytes(tw, filepath.Join("modelfiles", name.Host, name.Namespace, name.Model, name.Tag, "Modelfile"), []byte(modelfile)); err != nil {
+				errorExit(fmt.Sprintf("Failed to add Modelfile to bundle: %v", err))
+			}
+		}
+
+		bundleModels = append(bundleModels, bundleModelEntry{
+			Model:          name.String(),
+			ManifestDigest: "sha256:" + sha256Hex(manifestBytes),
+		})
+	}
+
+	bundleBytes, err := json.Marshal(bundleManifest{Models: bundleModels})
+	if err != nil {
+		errorExit(fmt.Sprintf("Failed to marshal bundle.json: %v", err))
+	}
+	if err := writeTarBytes(tw, "bundle.json", bundleBytes); err != nil {
+		errorExit(fmt.Sprintf("Failed to add bundle.json: %v", err))
+	}
+
+	var dedupedBytes int64
+	for hash, count := range blobRefCount {
+		if count > 1 {
+			dedupedBytes += int64(count-1) * blobSizes[hash]
+		}
+	}
+
+	fmt.Println("===================================================")
+	fmt.Printf("Bundle completed: %s\n", a.BundlePath)
+	fmt.Printf("Included %d models, deduplicated %s across the bundle\n", len(bundleModels), humanizeBytes(dedupedBytes))
+	fmt.Println("===================================================")
+}
+
+// manifestBlobHashes extracts every unique sha256 hash (config and layers) referenced by
+// an Ollama manifest, sorted for deterministic output.
+func manifestBlobHashes(manifestData map[string]interface{}) []string {
+	seen := map[string]bool{}
+	var hashes []string
+
+	addDigest := func(entry interface{}) {
+		entryMap, ok := entry.(map[string]interface{})
+		if !ok {
+			return
+		}
+		digest, _ := entryMap["digest"].(string)
+		hash := strings.TrimPrefix(digest, "sha256:")
+		if hash == "" || seen[hash] {
+			return
+		}
+		seen[hash] = true
+		hashes = append(hashes, hash)
+	}
+
+	addDigest(manifestData["config"])
+	if layers, ok := manifestData["layers"].([]interface{}); ok {
+		for _, layer := range layers {
+			addDigest(layer)
+		}
+	}
+
+	sort.Strings(hashes)
+	return hashes
+}
+
+// humanizeBytes renders a byte count as a short human-readable size, e.g. "3.2 GiB".
+func humanizeBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for n/div >= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
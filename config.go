@@ -0,0 +1,64 @@
+// config.go
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// defaultOllamaHost is used when neither -H nor $OLLAMA_HOST is set.
+const defaultOllamaHost = "http://127.0.0.1:11434"
+
+// resolveOllamaBaseDir determines the Ollama models directory, preferring an explicit
+// -o flag, then $OLLAMA_MODELS, then the OS-specific default Ollama uses itself.
+func resolveOllamaBaseDir(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if envDir := os.Getenv("OLLAMA_MODELS"); envDir != "" {
+		return envDir
+	}
+	return defaultOllamaBaseDir()
+}
+
+// defaultOllamaBaseDir mirrors where the Ollama daemon itself stores models by default
+// on each platform.
+func defaultOllamaBaseDir() string {
+	switch runtime.GOOS {
+	case "darwin":
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, ".ollama", "models")
+		}
+		return "/var/lib/ollama"
+	case "windows":
+		if localAppData := os.Getenv("LOCALAPPDATA"); localAppData != "" {
+			return filepath.Join(localAppData, "Ollama", "models")
+		}
+		return `C:\Ollama\models`
+	default:
+		return "/usr/share/ollama/.ollama/models"
+	}
+}
+
+// resolveOllamaHost determines the Ollama daemon address, preferring an explicit -H
+// flag, then $OLLAMA_HOST, then defaultOllamaHost.
+func resolveOllamaHost(flagValue string) string {
+	if flagValue != "" {
+		return withScheme(flagValue)
+	}
+	if envHost := os.Getenv("OLLAMA_HOST"); envHost != "" {
+		return withScheme(envHost)
+	}
+	return defaultOllamaHost
+}
+
+// withScheme prepends "http://" when host has no scheme, since OLLAMA_HOST is
+// conventionally scheme-less (e.g. "127.0.0.1:11434") but net/http requires one.
+func withScheme(host string) string {
+	if strings.Contains(host, "://") {
+		return host
+	}
+	return "http://" + host
+}
@@ -0,0 +1,264 @@
+// import.go
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ImportOptions controls how Import restores an archive produced by createTarGz.
+type ImportOptions struct {
+	ViaAPI     bool // POST a generated Modelfile to /api/create after writing blobs+manifests
+	DryRun     bool // Only list what would be written
+	VerifyOnly bool // Only recompute and verify blob digests, without writing anything
+}
+
+// Import streams the gzip+tar archive at archivePath, validating each blob's SHA-256
+// against its "sha256-<hash>" filename and writing manifests under
+// <OllamaBaseDir>/manifests/..., restoring an archive produced by createTarGz.
+func (a *App) Import(archivePath string, opts ImportOptions) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %v", err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("failed to read gzip stream: %v", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	var manifestRefs []string
+	var bundle *bundleManifest
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar entry: %v", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(header.Name, "blobs/sha256-"):
+			if err := a.importBlob(tr, header, opts); err != nil {
+				return err
+			}
+		case strings.HasPrefix(header.Name, "manifests/"):
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return fmt.Errorf("failed to read manifest %s: %v", header.Name, err)
+			}
+			if err := a.importManifest(header.Name, data, opts); err != nil {
+				return err
+			}
+			manifestRefs = append(manifestRefs, header.Name)
+		case strings.HasPrefix(header.Name, "modelfiles/"):
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %v", header.Name, err)
+			}
+			if err := a.importModelfile(header.Name, data, opts); err != nil {
+				return err
+			}
+		case header.Name == "bundle.json":
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return fmt.Errorf("failed to read bundle.json: %v", err)
+			}
+			var b bundleManifest
+			if err := json.Unmarshal(data, &b); err != nil {
+				return fmt.Errorf("failed to parse bundle.json: %v", err)
+			}
+			bundle = &b
+			fmt.Printf("Archive is a bundle containing %d models\n", len(b.Models))
+		default:
+			debugPrint(fmt.Sprintf("Skipping unrecognized archive entry: %s", header.Name), a.Debug)
+		}
+	}
+
+	if opts.DryRun || opts.VerifyOnly || !opts.ViaAPI {
+		return nil
+	}
+
+	// A bundle.json, when present, is authoritative for which models to register; a
+	// plain archive falls back to deriving model references from manifest paths.
+	var modelRefs []string
+	if bundle != nil {
+		for _, m := range bundle.Models {
+			modelRefs = append(modelRefs, m.Model)
+		}
+	} else {
+		for _, manifestName := range manifestRefs {
+			name, err := modelRefFromManifestPath(manifestName)
+			if err != nil {
+				debugPrint(fmt.Sprintf("Could not derive model reference for %s: %v", manifestName, err), a.Debug)
+				continue
+			}
+			modelRefs = append(modelRefs, name.String())
+		}
+	}
+
+	for _, modelRef := range modelRefs {
+		if err := createModelViaAPI(a.OllamaHost, modelRef); err != nil {
+			return fmt.Errorf("failed to register %s via /api/create: %v", modelRef, err)
+		}
+		fmt.Printf("Registered %s via %s/api/create\n", modelRef, a.OllamaHost)
+	}
+
+	return nil
+}
+
+// importBlob verifies the blob's content hash against its "sha256-<hash>" filename and,
+// unless opts.VerifyOnly or opts.DryRun is set, writes it under <OllamaBaseDir>/blobs/.
+// An existing blob whose content differs from the archive is left untouched and reported
+// as an error rather than silently overwritten.
+func (a *App) importBlob(tr *tar.Reader, header *tar.Header, opts ImportOptions) error {
+	hash := strings.TrimPrefix(filepath.Base(header.Name), "sha256-")
+
+	hasher := sha256.New()
+	var buf bytes.Buffer
+	if _, err := io.Copy(io.MultiWriter(hasher, &buf), tr); err != nil {
+		return fmt.Errorf("failed to read blob %s: %v", header.Name, err)
+	}
+
+	actualHash := hex.EncodeToString(hasher.Sum(nil))
+	if actualHash != hash {
+		return fmt.Errorf("blob %s failed digest verification: expected sha256-%s, got sha256-%s", header.Name, hash, actualHash)
+	}
+
+	if opts.VerifyOnly {
+		fmt.Printf("OK    %s\n", header.Name)
+		return nil
+	}
+	if opts.DryRun {
+		fmt.Printf("would write %s (%d bytes)\n", header.Name, buf.Len())
+		return nil
+	}
+
+	destPath := filepath.Join(a.OllamaBaseDir, "blobs", "sha256-"+hash)
+	if existing, err := os.ReadFile(destPath); err == nil {
+		if !bytes.Equal(existing, buf.Bytes()) {
+			return fmt.Errorf("refusing to overwrite existing blob %s with differing content", destPath)
+		}
+		debugPrint(fmt.Sprintf("Blob %s already present and identical, skipping", destPath), a.Debug)
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return fmt.Errorf("failed to create blobs directory: %v", err)
+	}
+	return os.WriteFile(destPath, buf.Bytes(), 0644)
+}
+
+// importManifest writes a manifest entry (named relative to the Ollama base dir, e.g.
+// "manifests/registry.ollama.ai/library/llama3/8b") under <OllamaBaseDir>/...
+func (a *App) importManifest(name string, data []byte, opts ImportOptions) error {
+	destPath, err := safeJoin(a.OllamaBaseDir, name)
+	if err != nil {
+		return fmt.Errorf("refusing to write manifest entry %q: %v", name, err)
+	}
+
+	if opts.VerifyOnly {
+		return nil
+	}
+	if opts.DryRun {
+		fmt.Printf("would write %s (%d bytes)\n", name, len(data))
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return fmt.Errorf("failed to create manifests directory: %v", err)
+	}
+	return os.WriteFile(destPath, data, 0644)
+}
+
+// importModelfile writes a Modelfile entry (named relative to the Ollama base dir, e.g.
+// "modelfiles/registry.ollama.ai/library/llama3/8b/Modelfile"), embedded by --show.
+func (a *App) importModelfile(name string, data []byte, opts ImportOptions) error {
+	destPath, err := safeJoin(a.OllamaBaseDir, name)
+	if err != nil {
+		return fmt.Errorf("refusing to write Modelfile entry %q: %v", name, err)
+	}
+
+	if opts.VerifyOnly {
+		return nil
+	}
+	if opts.DryRun {
+		fmt.Printf("would write %s (%d bytes)\n", name, len(data))
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return fmt.Errorf("failed to create modelfiles directory: %v", err)
+	}
+	return os.WriteFile(destPath, data, 0644)
+}
+
+// safeJoin joins baseDir and name the way filepath.Join would, but rejects an absolute
+// name or a cleaned result that escapes baseDir, guarding against tar-slip archive
+// entries like "manifests/../../etc/cron.d/x".
+func safeJoin(baseDir, name string) (string, error) {
+	if filepath.IsAbs(name) {
+		return "", fmt.Errorf("archive entry has an absolute path")
+	}
+
+	dest := filepath.Join(baseDir, name)
+	rel, err := filepath.Rel(baseDir, dest)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+		return "", fmt.Errorf("path escapes %s", baseDir)
+	}
+
+	return dest, nil
+}
+
+// modelRefFromManifestPath recovers a ModelName from a manifest archive entry name of the
+// form "manifests/<host>/<namespace>/<model>/<tag>".
+func modelRefFromManifestPath(name string) (ModelName, error) {
+	parts := strings.Split(name, "/")
+	if len(parts) != 5 || parts[0] != "manifests" {
+		return ModelName{}, fmt.Errorf("unrecognized manifest path %q", name)
+	}
+	return ModelName{Host: parts[1], Namespace: parts[2], Model: parts[3], Tag: parts[4]}, nil
+}
+
+// createModelViaAPI POSTs a generated Modelfile to /api/create, so importers don't need
+// to hand-write "FROM name:tag" themselves.
+func createModelViaAPI(host, modelRef string) error {
+	modelfile := fmt.Sprintf("FROM %s\n", modelRef)
+	body, err := json.Marshal(map[string]string{
+		"name":      modelRef,
+		"modelfile": modelfile,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.Post(host+"/api/create", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status code %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
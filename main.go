@@ -16,13 +16,23 @@ import (
 )
 
 var (
-	ollamaBaseDir = flag.String("o", "/var/lib/ollama", "Ollama models directory")
+	ollamaBaseDir = flag.String("o", "", "Ollama models directory (default: $OLLAMA_MODELS, or an OS-specific default)")
 	outputDir     = flag.String("d", "./ollama-export", "Destination directory for exported models")
 	debug         = flag.Bool("debug", false, "Enable debug messages")
 	modelName     = flag.String("m", "", "Model to export (optional)") // New flag for model selection
+	format        = flag.String("format", "tar", "Export format: tar (default custom tar.gz layout) or oci (OCI image layout archive)")
+	bundleOut     = flag.String("bundle", "", "Write all requested models into a single deduplicated bundle archive at this path")
+	ollamaHost    = flag.String("H", "", "Ollama host (default: $OLLAMA_HOST, or http://127.0.0.1:11434)")
+	pull          = flag.Bool("pull", false, "Pull any requested model with no local manifest via POST <host>/api/pull before exporting")
+	show          = flag.Bool("show", false, "Fetch the Modelfile via /api/show and embed it into the archive as Modelfile")
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		runImport(os.Args[2:])
+		return
+	}
+
 	flag.Usage = usage
 	// Add -h and --help flags
 	flag.Bool("h", false, "Show this help message")
@@ -38,13 +48,61 @@ func main() {
 		}
 	}
 
-	app := NewApp(*ollamaBaseDir, *outputDir, *debug)
+	resolvedBaseDir := resolveOllamaBaseDir(*ollamaBaseDir)
+	resolvedHost := resolveOllamaHost(*ollamaHost)
+	debugPrint(fmt.Sprintf("Resolved Ollama base dir: %s", resolvedBaseDir), *debug)
+	debugPrint(fmt.Sprintf("Resolved Ollama host: %s", resolvedHost), *debug)
+
+	app := NewApp(resolvedBaseDir, *outputDir, *debug)
+	if err := app.SetFormat(*format); err != nil {
+		errorExit(err.Error())
+	}
+	app.BundlePath = *bundleOut
+	app.OllamaHost = resolvedHost
+	app.Pull = *pull
+	app.Show = *show
 
 	// Pass positional arguments to the App
 	args := flag.Args()
 	app.Run(args...)
 }
 
+// runImport implements the `gollama-export import file.tar.gz` subcommand, restoring
+// an archive produced by createTarGz into a target Ollama directory.
+func runImport(args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	importOllamaDir := fs.String("o", "", "Ollama models directory to import into (default: $OLLAMA_MODELS, or an OS-specific default)")
+	importHost := fs.String("H", "", "Ollama host used by --via-api (default: $OLLAMA_HOST, or http://127.0.0.1:11434)")
+	importDebug := fs.Bool("debug", false, "Enable debug messages")
+	viaAPI := fs.Bool("via-api", false, "POST a generated Modelfile to the Ollama host's /api/create after import")
+	dryRun := fs.Bool("dry-run", false, "List what would be written without writing anything")
+	verifyOnly := fs.Bool("verify-only", false, "Only recompute and verify blob digests, without writing anything")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fmt.Println("Usage: gollama-export import [OPTIONS] <archive.tar.gz>")
+		fmt.Println("  -o <directory>   : Ollama base directory to import into (default: $OLLAMA_MODELS, or an OS-specific default)")
+		fmt.Println("  -H <host>        : Ollama host used by --via-api (default: $OLLAMA_HOST, or http://127.0.0.1:11434)")
+		fmt.Println("  --via-api        : Register the model via POST <host>/api/create")
+		fmt.Println("  --dry-run        : List what would be written without writing anything")
+		fmt.Println("  --verify-only    : Only recompute and verify blob digests, without writing anything")
+		fmt.Println("  --debug          : Enable debug messages")
+		os.Exit(1)
+	}
+
+	resolvedBaseDir := resolveOllamaBaseDir(*importOllamaDir)
+	resolvedHost := resolveOllamaHost(*importHost)
+	debugPrint(fmt.Sprintf("Resolved Ollama base dir: %s", resolvedBaseDir), *importDebug)
+	debugPrint(fmt.Sprintf("Resolved Ollama host: %s", resolvedHost), *importDebug)
+
+	app := NewApp(resolvedBaseDir, "", *importDebug)
+	app.OllamaHost = resolvedHost
+	opts := ImportOptions{ViaAPI: *viaAPI, DryRun: *dryRun, VerifyOnly: *verifyOnly}
+	if err := app.Import(fs.Arg(0), opts); err != nil {
+		errorExit(err.Error())
+	}
+}
+
 func debugPrint(msg string, debug bool) {
 	if debug {
 		fmt.Println("[DEBUG]", msg)
@@ -58,12 +116,20 @@ func errorExit(msg string) {
 
 func usage() {
 	fmt.Println("Usage: goexport-ollama [OPTIONS] [model...]")
-	fmt.Println("  -o, --ollama-dir <directory> : Ollama base directory (default: /var/lib/ollama)")
+	fmt.Println("  -o, --ollama-dir <directory> : Ollama base directory (default: $OLLAMA_MODELS, or an OS-specific default)")
+	fmt.Println("  -H <host>                    : Ollama host (default: $OLLAMA_HOST, or http://127.0.0.1:11434)")
 	fmt.Println("  -d, --output-dir <directory> : Output directory for export (default: ./ollama-export)")
 	fmt.Println("  -m, --model <model_name>     : Model to export (e.g., 'llama2:latest')")
+	fmt.Println("  --format <tar|oci>           : Export format: tar (default) or oci (OCI image layout archive)")
+	fmt.Println("  --bundle <file.tar.gz>       : Export all requested models into one deduplicated bundle archive")
+	fmt.Println("  --pull                       : Pull models with no local manifest via /api/pull before exporting")
+	fmt.Println("  --show                       : Embed the Modelfile (via /api/show) into the archive")
 	fmt.Println("  -h, --help                   : Show this help message")
 	fmt.Println("  --debug                      : Enable debug messages")
 	fmt.Println()
 	fmt.Println("If no model is specified via flag or arguments, all available models will be exported.")
+	fmt.Println()
+	fmt.Println("Subcommands:")
+	fmt.Println("  import [OPTIONS] <archive.tar.gz> : Restore an archive produced by this tool (see 'import -h')")
 	os.Exit(0)
 }
@@ -0,0 +1,97 @@
+// model_name.go
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	defaultModelHost      = "registry.ollama.ai"
+	defaultModelNamespace = "library"
+	defaultModelTag       = "latest"
+)
+
+// ModelName represents a fully qualified Ollama model reference, e.g.
+// "registry.ollama.ai/library/llama3:8b" or "hf.co/user/model:latest".
+type ModelName struct {
+	Host      string
+	Namespace string
+	Model     string
+	Tag       string
+}
+
+// ParseModelName parses an Ollama model reference of the form
+// "[host/][namespace/]model[:tag]", defaulting Host to registry.ollama.ai, Namespace to
+// library, and Tag to latest. This mirrors the reference grammar Ollama itself accepts,
+// so models pulled from non-default registries or namespaces are no longer silently
+// skipped.
+func ParseModelName(ref string) (ModelName, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return ModelName{}, fmt.Errorf("model reference is empty")
+	}
+
+	name := ModelName{
+		Host:      defaultModelHost,
+		Namespace: defaultModelNamespace,
+		Tag:       defaultModelTag,
+	}
+
+	// The tag is the part after the last ':', as long as that ':' isn't part of a
+	// "host:port" component (which would still have a '/' after it).
+	if i := strings.LastIndex(ref, ":"); i != -1 && !strings.Contains(ref[i:], "/") {
+		name.Tag = ref[i+1:]
+		ref = ref[:i]
+	}
+
+	parts := strings.Split(ref, "/")
+	switch len(parts) {
+	case 1:
+		name.Model = parts[0]
+	case 2:
+		// A first segment containing '.' or ':' (e.g. "localhost:11434" or "myhost.com")
+		// is a host, not a namespace, per Ollama's own reference grammar.
+		if looksLikeHost(parts[0]) {
+			name.Host, name.Model = parts[0], parts[1]
+		} else {
+			name.Namespace, name.Model = parts[0], parts[1]
+		}
+	case 3:
+		name.Host, name.Namespace, name.Model = parts[0], parts[1], parts[2]
+	default:
+		return ModelName{}, fmt.Errorf("invalid model reference %q", ref)
+	}
+
+	if name.Model == "" {
+		return ModelName{}, fmt.Errorf("invalid model reference %q: missing model name", ref)
+	}
+
+	return name, nil
+}
+
+// looksLikeHost reports whether a reference segment is a registry host rather than a
+// namespace, i.e. it contains a '.' (domain) or ':' (port), matching Ollama's grammar.
+func looksLikeHost(segment string) bool {
+	return strings.ContainsAny(segment, ".:")
+}
+
+// String renders the fully qualified reference, e.g. "registry.ollama.ai/library/llama3:8b".
+func (m ModelName) String() string {
+	return fmt.Sprintf("%s/%s/%s:%s", m.Host, m.Namespace, m.Model, m.Tag)
+}
+
+// ManifestPath returns this model's manifest path relative to an Ollama base directory,
+// e.g. "manifests/registry.ollama.ai/library/llama3/8b".
+func (m ModelName) ManifestPath() string {
+	return filepath.Join("manifests", m.Host, m.Namespace, m.Model, m.Tag)
+}
+
+// SafeFileName renders a filesystem-safe name for output files that preserves host and
+// namespace information rather than discarding it, e.g.
+// "registry.ollama.ai-library-llama3-8b".
+func (m ModelName) SafeFileName() string {
+	safe := strings.ReplaceAll(m.String(), "/", "-")
+	return strings.ReplaceAll(safe, ":", "-")
+}
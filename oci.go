@@ -0,0 +1,211 @@
+// oci.go
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ociDescriptor mirrors the OCI content descriptor used in image manifests and the index.
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type ociManifest struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType"`
+	Config        ociDescriptor   `json:"config"`
+	Layers        []ociDescriptor `json:"layers"`
+}
+
+type ociIndex struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType"`
+	Manifests     []ociDescriptor `json:"manifests"`
+}
+
+type ociImageLayout struct {
+	ImageLayoutVersion string `json:"imageLayoutVersion"`
+}
+
+// createOCIArchive writes an OCI image layout (https://github.com/opencontainers/image-spec)
+// tar.gz archive for a single model, so the export can be consumed directly by
+// `skopeo copy oci-archive:...`, containerd, buildah, and other OCI-aware tools instead
+// of only the ad-hoc manifest+blob tree produced by createTarGz.
+func createOCIArchive(outputPath, baseDir, modelRef string, manifestData map[string]interface{}) error {
+	configDesc, err := ociDescriptorFromManifestEntry(baseDir, manifestData["config"])
+	if err != nil {
+		return fmt.Errorf("failed to read config descriptor: %v", err)
+	}
+
+	layersRaw, _ := manifestData["layers"].([]interface{})
+	layerDescs := make([]ociDescriptor, 0, len(layersRaw))
+	for _, l := range layersRaw {
+		desc, err := ociDescriptorFromManifestEntry(baseDir, l)
+		if err != nil {
+			return fmt.Errorf("failed to read layer descriptor: %v", err)
+		}
+		layerDescs = append(layerDescs, desc)
+	}
+	sort.Slice(layerDescs, func(i, j int) bool { return layerDescs[i].Digest < layerDescs[j].Digest })
+
+	manifest := ociManifest{
+		SchemaVersion: 2,
+		MediaType:     "application/vnd.oci.image.manifest.v1+json",
+		Config:        configDesc,
+		Layers:        layerDescs,
+	}
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	manifestDigest := "sha256:" + sha256Hex(manifestBytes)
+
+	index := ociIndex{
+		SchemaVersion: 2,
+		MediaType:     "application/vnd.oci.image.index.v1+json",
+		Manifests: []ociDescriptor{
+			{
+				MediaType: manifest.MediaType,
+				Digest:    manifestDigest,
+				Size:      int64(len(manifestBytes)),
+				Annotations: map[string]string{
+					"org.opencontainers.image.ref.name": modelRef,
+				},
+			},
+		},
+	}
+	indexBytes, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+
+	layoutBytes, err := json.Marshal(ociImageLayout{ImageLayoutVersion: "1.0.0"})
+	if err != nil {
+		return err
+	}
+
+	outFile, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	defer outFile.Close()
+
+	gz := gzip.NewWriter(outFile)
+	defer gz.Close()
+
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+
+	if err := writeTarBytes(tw, "oci-layout", layoutBytes); err != nil {
+		return err
+	}
+	if err := writeTarBytes(tw, "index.json", indexBytes); err != nil {
+		return err
+	}
+	if err := writeTarBytes(tw, ociBlobTarPath(manifestDigest), manifestBytes); err != nil {
+		return err
+	}
+
+	blobDescs := append([]ociDescriptor{configDesc}, layerDescs...)
+	sort.Slice(blobDescs, func(i, j int) bool { return blobDescs[i].Digest < blobDescs[j].Digest })
+	for _, desc := range blobDescs {
+		hash := strings.TrimPrefix(desc.Digest, "sha256:")
+		src := filepath.Join(baseDir, "blobs", "sha256-"+hash)
+		if err := writeTarFile(tw, ociBlobTarPath(desc.Digest), src); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ociDescriptorFromManifestEntry converts an Ollama manifest's "config" or "layers[i]"
+// entry into an OCI descriptor, recomputing Size from the blob on disk (via os.Stat)
+// rather than trusting the manifest's own size field, so a re-export byte-matches.
+func ociDescriptorFromManifestEntry(baseDir string, entry interface{}) (ociDescriptor, error) {
+	entryMap, ok := entry.(map[string]interface{})
+	if !ok {
+		return ociDescriptor{}, fmt.Errorf("manifest entry is not an object")
+	}
+
+	mediaType, _ := entryMap["mediaType"].(string)
+	digest, _ := entryMap["digest"].(string)
+	if digest == "" {
+		return ociDescriptor{}, fmt.Errorf("manifest entry is missing a digest")
+	}
+
+	hash := strings.TrimPrefix(digest, "sha256:")
+	info, err := os.Stat(filepath.Join(baseDir, "blobs", "sha256-"+hash))
+	if err != nil {
+		return ociDescriptor{}, fmt.Errorf("blob %s: %v", digest, err)
+	}
+
+	return ociDescriptor{
+		MediaType: mediaType,
+		Digest:    digest,
+		Size:      info.Size(),
+	}, nil
+}
+
+// ociBlobTarPath returns the canonical "blobs/sha256/<hash>" archive entry name for a digest.
+func ociBlobTarPath(digest string) string {
+	return "blobs/sha256/" + strings.TrimPrefix(digest, "sha256:")
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// writeTarBytes writes an in-memory byte slice as a tar entry.
+func writeTarBytes(tw *tar.Writer, name string, data []byte) error {
+	header := &tar.Header{
+		Name: name,
+		Mode: 0644,
+		Size: int64(len(data)),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// writeTarFile writes the contents of the file at path as a tar entry named name.
+func writeTarFile(tw *tar.Writer, name, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = name
+
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, file)
+	return err
+}
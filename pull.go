@@ -0,0 +1,114 @@
+// pull.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// pullProgressEvent mirrors one line of the newline-delimited JSON progress stream
+// returned by POST /api/pull.
+type pullProgressEvent struct {
+	Status    string `json:"status"`
+	Digest    string `json:"digest"`
+	Total     int64  `json:"total"`
+	Completed int64  `json:"completed"`
+}
+
+// pullModel POSTs to /api/pull on a.OllamaHost and streams the progress, rendering
+// `{"status":"downloading",...}` events as a completed/total progress line so models
+// missing locally can be pulled before exporting instead of only working on models
+// already materialized on disk.
+func (a *App) pullModel(name ModelName) error {
+	body, err := json.Marshal(map[string]string{"name": name.String()})
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.Post(a.OllamaHost+"/api/pull", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %v", a.OllamaHost, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status code %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lastDigest string
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var event pullProgressEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			debugPrint(fmt.Sprintf("Could not parse pull progress line: %v", err), a.Debug)
+			continue
+		}
+
+		switch {
+		case event.Digest != "" && event.Total > 0:
+			if event.Digest != lastDigest {
+				fmt.Println()
+				lastDigest = event.Digest
+			}
+			fmt.Printf("\r  %s: %d/%d bytes (%.0f%%)", event.Digest, event.Completed, event.Total, 100*float64(event.Completed)/float64(event.Total))
+		default:
+			fmt.Printf("\n  %s\n", event.Status)
+		}
+	}
+	fmt.Println()
+
+	return scanner.Err()
+}
+
+// showResponse is the subset of /api/show's response this tool needs.
+type showResponse struct {
+	Modelfile string `json:"modelfile"`
+}
+
+// fetchModelfile calls /api/show on a.OllamaHost to retrieve the Modelfile for name, so
+// it can be embedded into the export archive and importers can recreate the model with
+// `ollama create -f Modelfile` instead of hand-writing "FROM name:tag".
+func (a *App) fetchModelfile(name ModelName) (string, error) {
+	body, err := json.Marshal(map[string]string{"name": name.String()})
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := http.Post(a.OllamaHost+"/api/show", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to reach %s: %v", a.OllamaHost, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("status code %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	var show showResponse
+	if err := json.Unmarshal(respBody, &show); err != nil {
+		return "", fmt.Errorf("failed to unmarshal response body: %v", err)
+	}
+	if show.Modelfile == "" {
+		return "", fmt.Errorf("response did not include a modelfile")
+	}
+
+	return show.Modelfile, nil
+}
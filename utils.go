@@ -3,8 +3,12 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 )
 
 type OllamaTagsResponse struct {
@@ -13,8 +17,20 @@ type OllamaTagsResponse struct {
 	} `json:"models"`
 }
 
-func getOllamaModelsWithTags() ([]string, error) {
-	resp, err := http.Get("http://localhost:11434/api/tags")
+// getOllamaModelsWithTags returns every model known to Ollama. It prefers the HTTP API,
+// but falls back to walking the manifests tree on disk (returning fully qualified
+// "host/namespace/model:tag" names) when the API is unreachable.
+func (a *App) getOllamaModelsWithTags() ([]string, error) {
+	models, err := fetchModelsFromAPI(a.OllamaHost)
+	if err == nil {
+		return models, nil
+	}
+	debugPrint(fmt.Sprintf("Ollama API unreachable (%v), falling back to filesystem discovery", err), a.Debug)
+	return a.discoverModelsFromFilesystem()
+}
+
+func fetchModelsFromAPI(host string) ([]string, error) {
+	resp, err := http.Get(host + "/api/tags")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get models from ollama api: %v", err)
 	}
@@ -40,4 +56,37 @@ func getOllamaModelsWithTags() ([]string, error) {
 	}
 
 	return models, nil
-}
\ No newline at end of file
+}
+
+// discoverModelsFromFilesystem walks <OllamaBaseDir>/manifests/<host>/<namespace>/<model>/<tag>
+// and returns every model found as a fully qualified reference.
+func (a *App) discoverModelsFromFilesystem() ([]string, error) {
+	manifestsRoot := filepath.Join(a.OllamaBaseDir, "manifests")
+
+	var models []string
+	err := filepath.Walk(manifestsRoot, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(manifestsRoot, path)
+		if err != nil {
+			return err
+		}
+		parts := strings.Split(rel, string(os.PathSeparator))
+		if len(parts) != 4 {
+			return nil
+		}
+		name := ModelName{Host: parts[0], Namespace: parts[1], Model: parts[2], Tag: parts[3]}
+		models = append(models, name.String())
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover models from filesystem: %v", err)
+	}
+
+	sort.Strings(models)
+	return models, nil
+}